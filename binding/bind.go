@@ -1,6 +1,7 @@
 package binding
 
 import (
+	"context"
 	"net/http"
 	"reflect"
 	_ "unsafe"
@@ -25,10 +26,14 @@ const (
 
 // Binding binding and verification tool for http request
 type Binding struct {
-	level          Level
-	vd             *validator.Validator
-	recvs          goutil.Map
-	bindErrFactory func(failField, msg string) error
+	level                   Level
+	vd                      *validator.Validator
+	recvs                   goutil.Map
+	bindErrFactory          func(failField, msg string) error
+	validatingErrFactory    func(failField, msg string) error
+	bodyCodecs              map[string]BodyCodec
+	bodyCodecsByContentType map[string]BodyCodec
+	defaultBodyCodec        BodyCodec
 }
 
 // New creates a binding tool.
@@ -39,10 +44,12 @@ func New(tagName string) *Binding {
 		tagName = "api"
 	}
 	b := &Binding{
-		vd:    validator.New(tagName),
-		recvs: goutil.AtomicMap(),
+		vd:                      validator.New(tagName),
+		recvs:                   goutil.AtomicMap(),
+		bodyCodecs:              make(map[string]BodyCodec, 8),
+		bodyCodecsByContentType: make(map[string]BodyCodec, 8),
 	}
-	return b.SetLevel(FirstAndTagged).SetErrorFactory(nil, nil)
+	return b.SetLevel(FirstAndTagged).SetErrorFactory(nil, nil).registerBuiltinBodyCodecs()
 }
 
 // SetLevel set the level of handling tags.
@@ -72,22 +79,29 @@ func (b *Binding) SetErrorFactory(bindErrFactory, validatingErrFactory func(fail
 		validatingErrFactory = defaultValidatingErrFactory
 	}
 	b.bindErrFactory = bindErrFactory
+	b.validatingErrFactory = validatingErrFactory
 	b.vd.SetErrorFactory(validatingErrFactory)
 	return b
 }
 
 // BindAndValidate binds the request parameters and validates them if needed.
+// NOTE:
+//  With the default error factories, the returned error is an Errors
+//  aggregate whenever one or more struct fields failed; use errors.As to
+//  recover an individual *FieldError,
+//  which exposes Index() for the failing element of a slice/array field.
 func (b *Binding) BindAndValidate(structPointer interface{}, req *http.Request, pathParams PathParams) error {
 	v, err := b.structValueOf(structPointer)
 	if err != nil {
-		return err
+		return asErrors(err)
 	}
-	hasVd, err := b.bind(v, req, pathParams)
+	hasVd, err := b.bind(context.Background(), v, req, pathParams)
 	if err != nil {
-		return err
+		return asErrors(err)
 	}
 	if hasVd {
-		return b.vd.Validate(v)
+		recv, _ := b.getObjOrPrepare(v)
+		return asErrors(b.validate(recv, v))
 	}
 	return nil
 }
@@ -96,15 +110,25 @@ func (b *Binding) BindAndValidate(structPointer interface{}, req *http.Request,
 func (b *Binding) Bind(structPointer interface{}, req *http.Request, pathParams PathParams) error {
 	v, err := b.structValueOf(structPointer)
 	if err != nil {
-		return err
+		return asErrors(err)
 	}
-	_, err = b.bind(v, req, pathParams)
-	return err
+	_, err = b.bind(context.Background(), v, req, pathParams)
+	return asErrors(err)
 }
 
 // Validate validates whether the fields of v is valid.
+// NOTE:
+//  See BindAndValidate for the shape of the returned error.
 func (b *Binding) Validate(value interface{}) error {
-	return b.vd.Validate(value)
+	v, err := b.structValueOf(value)
+	if err != nil {
+		return asErrors(err)
+	}
+	recv, err := b.getObjOrPrepare(v)
+	if err != nil {
+		return asErrors(err)
+	}
+	return asErrors(b.validate(recv, v))
 }
 
 func (b *Binding) structValueOf(structPointer interface{}) (reflect.Value, error) {
@@ -155,7 +179,7 @@ func (b *Binding) getObjOrPrepare(value reflect.Value) (*receiver, error) {
 				evals = fh.EvalFuncs()
 				for es := range evals {
 					switch v := es.Name(); v {
-					case "raw_body", "body", "query", "path", "header", "cookie", "required":
+					case "raw_body", "body", "query", "path", "header", "cookie", "required", "omitempty":
 						canHandle = true
 						break
 					}
@@ -195,6 +219,10 @@ func (b *Binding) getObjOrPrepare(value reflect.Value) (*receiver, error) {
 				p.required = tagexpr.FakeBool(eval())
 				continue L
 
+			case "omitempty":
+				p.omitempty = tagexpr.FakeBool(eval())
+				continue L
+
 			case "raw_body":
 				recv.hasRawBody = true
 				in = raw_body
@@ -237,13 +265,97 @@ func (b *Binding) getObjOrPrepare(value reflect.Value) (*receiver, error) {
 		return nil, b.bindErrFactory(errExprSelector.String(), errMsg)
 	}
 
+	if !recv.hasVd {
+		recv.hasVd = b.hasDeepMatchExpr(value.Type())
+	}
+
 	recv.initParams()
 
 	b.recvs.Store(runtimeTypeID, recv)
 	return recv, nil
 }
 
-func (b *Binding) bind(value reflect.Value, req *http.Request, pathParams PathParams) (hasVd bool, err error) {
+// hasDeepMatchExpr reports whether t, or any struct reachable through its
+// fields (including through a slice/array/map element), carries a match
+// expression (the bare `$...` clause of the `api` tag) anywhere.
+// expr.RangeFields above only visits t's own statically-reachable fields -
+// it never walks into a slice/array/map element - so a struct whose only
+// match expression lives on a slice element's field (e.g. Items []Item
+// with Item.Name tagged `api:"$!=''"`) would otherwise leave recv.hasVd
+// false forever, silently skipping validation for the whole struct.
+//
+// The probe below runs against a synthetic value of t with every
+// slice/array/map field populated with one zero-value element, so
+// expr.Range - which does descend into populated elements - actually
+// visits their field types instead of treating them as empty.
+func (b *Binding) hasDeepMatchExpr(t reflect.Type) bool {
+	probe := reflect.New(t).Elem()
+	populateProbe(probe, 0)
+	expr, err := b.vd.VM().Run(probe)
+	if err != nil {
+		return false
+	}
+	var found bool
+	expr.Range(func(eh *tagexpr.ExprHandler) error {
+		if eh.ExprSelector().Name() == validator.MatchExprName {
+			found = true
+		}
+		return nil
+	})
+	return found
+}
+
+// maxProbeDepth bounds populateProbe's recursion so a self-referential
+// type (e.g. a tree node holding a slice of itself) can't recurse forever.
+const maxProbeDepth = 8
+
+// populateProbe ensures every slice/array/map field reachable from v has
+// at least one zero-value element, recursing into nested structs, so a
+// tagexpr Range pass actually visits element types instead of treating an
+// empty slice/array/map as having none.
+func populateProbe(v reflect.Value, depth int) {
+	if depth > maxProbeDepth {
+		return
+	}
+	v = derefValue(v)
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < v.NumField(); i++ {
+		fv := v.Field(i)
+		if !fv.CanSet() {
+			continue
+		}
+		switch fv.Kind() {
+		case reflect.Slice:
+			if fv.Len() == 0 {
+				fv.Set(reflect.MakeSlice(fv.Type(), 1, 1))
+			}
+			populateProbe(fv.Index(0), depth+1)
+		case reflect.Array:
+			if fv.Len() > 0 {
+				populateProbe(fv.Index(0), depth+1)
+			}
+		case reflect.Map:
+			if fv.IsNil() {
+				fv.Set(reflect.MakeMap(fv.Type()))
+			}
+			key := reflect.New(fv.Type().Key()).Elem()
+			val := reflect.New(fv.Type().Elem()).Elem()
+			populateProbe(val, depth+1)
+			fv.SetMapIndex(key, val)
+		case reflect.Ptr:
+			if fv.IsNil() && fv.Type().Elem().Kind() == reflect.Struct {
+				fv.Set(reflect.New(fv.Type().Elem()))
+			}
+			populateProbe(fv, depth+1)
+		case reflect.Struct:
+			populateProbe(fv, depth+1)
+		}
+	}
+}
+
+func (b *Binding) bind(ctx context.Context, value reflect.Value, req *http.Request, pathParams PathParams) (hasVd bool, err error) {
 	recv, err := b.getObjOrPrepare(value)
 	if err != nil {
 		return false, err
@@ -254,45 +366,66 @@ func (b *Binding) bind(value reflect.Value, req *http.Request, pathParams PathPa
 		return false, err
 	}
 
-	bodyCodec := recv.getBodyCodec(req)
+	var codec BodyCodec
+	var isForm bool
+	if req != nil {
+		ct := req.Header.Get("Content-Type")
+		if isFormContentType(ct) {
+			isForm = true
+		} else if recv.hasBody || recv.hasAuto {
+			codec = b.bodyCodecFor(ct)
+		}
+	}
 
-	bodyBytes, err := recv.getBodyBytes(req, bodyCodec == jsonBody)
+	bodyBytes, err := recv.getBodyBytes(req, codec != nil || recv.hasRawBody)
 	if err != nil {
 		return false, err
 	}
 
-	postForm, err := recv.getPostForm(req, bodyCodec == formBody)
+	postForm, err := recv.getPostForm(req, isForm || recv.hasBody || recv.hasAuto)
 	if err != nil {
 		return false, err
 	}
 
+	var decodedBy BodyCodec
+	if codec != nil && len(bodyBytes) > 0 {
+		if err = codec.Unmarshal(bodyBytes, value, recv.bodyParams()); err != nil {
+			return recv.hasVd, b.bindErrFactory("", err.Error())
+		}
+		decodedBy = codec
+	}
+
 	queryValues := recv.getQuery(req)
 	cookies := recv.getCookies(req)
+	var header http.Header
+	if req != nil {
+		header = req.Header
+	}
 
-	for _, param := range recv.params {
-		switch param.in {
-		case query:
-			_, err = param.bindQuery(expr, queryValues)
-		case path:
-			_, err = param.bindPath(expr, pathParams)
-		case header:
-			_, err = param.bindHeader(expr, req.Header)
-		case cookie:
-			err = param.bindCookie(expr, cookies)
-		case body:
-			_, err = param.bindBody(expr, bodyCodec, postForm, bodyBytes)
-		case raw_body:
-			err = param.bindRawBody(expr, bodyBytes)
-		default:
-			var found bool
-			found, err = param.bindBody(expr, bodyCodec, postForm, bodyBytes)
-			if !found {
-				_, err = param.bindQuery(expr, queryValues)
-			}
-		}
-		if err != nil {
+	for _, step := range []func() error{
+		func() error { return bindPathParams(ctx, recv.params, expr, pathParams) },
+		func() error { return bindQueryParams(ctx, recv.params, expr, queryValues) },
+		func() error { return bindHeaderParams(ctx, recv.params, expr, header) },
+		func() error { return bindCookieParams(ctx, recv.params, expr, cookies) },
+		func() error { return bindFormParams(ctx, recv.params, expr, postForm) },
+		func() error { return bindRawBodyParams(recv.params, expr, bodyBytes) },
+		// An untagged ("auto") field falls back to the query string only
+		// when no BodyCodec already had a chance to decode it: once a
+		// structured codec (JSON, MsgPack, ...) has run, it is trusted
+		// for every body/auto field, since codec.Unmarshal does not
+		// report which individual fields it actually found.
+		func() error { return bindAutoParams(ctx, recv.params, expr, postForm, queryValues, decodedBy) },
+	} {
+		if err = step(); err != nil {
 			return recv.hasVd, err
 		}
 	}
+
+	for _, param := range recv.params {
+		if param.required && isEmptyValue(param.fieldValue(expr, false)) {
+			return recv.hasVd, b.bindErrFactory(param.fieldSelector, "required")
+		}
+	}
+
 	return recv.hasVd, nil
 }
@@ -0,0 +1,100 @@
+package binding
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type testPathParams map[string]string
+
+func (p testPathParams) Get(key string) (string, bool) {
+	v, ok := p[key]
+	return v, ok
+}
+
+// TestBindAndValidatePopulatesStruct is an end-to-end regression test for
+// the fieldValue bug: it binds a real *http.Request into a real struct
+// pointer and asserts the pointer itself was populated, not a throwaway
+// instance discarded after registration.
+func TestBindAndValidatePopulatesStruct(t *testing.T) {
+	type Req struct {
+		ID     string `api:"path:'id'"`
+		Page   int    `api:"query:'page'"`
+		Token  string `api:"header:'X-Token';required:true"`
+		Cookie string `api:"cookie:'session'"`
+		Name   string `api:"required:true;$!=''"`
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "/users/42?page=3", strings.NewReader(url.Values{
+		"Name": {"ada"},
+	}.Encode()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Token", "secret")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "abc123"})
+
+	b := New("")
+	out := new(Req)
+	if err := b.BindAndValidate(out, req, testPathParams{"id": "42"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.ID != "42" {
+		t.Errorf("ID = %q, want %q", out.ID, "42")
+	}
+	if out.Page != 3 {
+		t.Errorf("Page = %d, want 3", out.Page)
+	}
+	if out.Token != "secret" {
+		t.Errorf("Token = %q, want %q", out.Token, "secret")
+	}
+	if out.Cookie != "abc123" {
+		t.Errorf("Cookie = %q, want %q", out.Cookie, "abc123")
+	}
+	if out.Name != "ada" {
+		t.Errorf("Name = %q, want %q", out.Name, "ada")
+	}
+}
+
+// TestBindAndValidateConcurrentRequestsDoNotShareState guards against the
+// throwaway-receiver-instance data race: many goroutines bind distinct
+// *http.Requests of the same struct type concurrently, and each must see
+// only its own values.
+func TestBindAndValidateConcurrentRequestsDoNotShareState(t *testing.T) {
+	type Req struct {
+		Page int `api:"query:'page'"`
+	}
+
+	b := New("")
+	const n = 64
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			req, err := http.NewRequest(http.MethodGet, "/?page="+strconv.Itoa(i), nil)
+			if err != nil {
+				errs <- err
+				return
+			}
+			out := new(Req)
+			if err := b.BindAndValidate(out, req, nil); err != nil {
+				errs <- err
+				return
+			}
+			if out.Page != i {
+				errs <- fmt.Errorf("goroutine %d saw Page=%d", i, out.Page)
+				return
+			}
+			errs <- nil
+		}(i)
+	}
+	for i := 0; i < n; i++ {
+		if err := <-errs; err != nil {
+			t.Error(err)
+		}
+	}
+}
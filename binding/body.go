@@ -0,0 +1,151 @@
+package binding
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+)
+
+// peekedBody wraps an already-read request body so handler code can still
+// Read it afterwards, while also exposing the raw bytes it cached to a
+// later PeekBody/Bind call against the same *http.Request. It carries no
+// package-level state: the cached bytes live exactly as long as req.Body
+// does, so they are garbage collected with the request instead of leaking
+// for the life of the process.
+type peekedBody struct {
+	*bytes.Reader
+	raw []byte
+}
+
+func newPeekedBody(raw []byte) *peekedBody {
+	return &peekedBody{Reader: bytes.NewReader(raw), raw: raw}
+}
+
+// Close implements io.ReadCloser. The bytes are already in memory, so
+// there is nothing to release.
+func (b *peekedBody) Close() error { return nil }
+
+// PeekBody reads req's body once, caches the bytes on req.Body itself, and
+// replaces req.Body with a reader over those bytes so ordinary handler code
+// can still read it afterwards. A later PeekBody or Bind/BindAndValidate
+// call against the same *http.Request reuses the cached bytes instead of
+// reading the body again, which makes it cheap to try several candidate
+// struct types against one polymorphic payload.
+func PeekBody(req *http.Request) ([]byte, error) {
+	if raw, ok := cachedPeekedBody(req); ok {
+		return raw, nil
+	}
+	if req == nil || req.Body == nil {
+		return nil, nil
+	}
+	raw, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	req.Body.Close()
+	req.Body = newPeekedBody(raw)
+	return raw, nil
+}
+
+// cachedPeekedBody returns the bytes previously cached for req by PeekBody,
+// if any.
+func cachedPeekedBody(req *http.Request) ([]byte, bool) {
+	if req == nil || req.Body == nil {
+		return nil, false
+	}
+	pb, ok := req.Body.(*peekedBody)
+	if !ok {
+		return nil, false
+	}
+	return pb.raw, true
+}
+
+// BindBodyAndValidate is BindAndValidate for callers that already have the
+// request body in hand (from PeekBody, a message queue payload, or a
+// previous, unsuccessful Bind attempt against another struct type): it
+// binds and validates structPointer against bodyBytes/contentType directly,
+// without reading a request at all.
+func (b *Binding) BindBodyAndValidate(structPointer interface{}, bodyBytes []byte, contentType string, pathParams PathParams) error {
+	v, err := b.structValueOf(structPointer)
+	if err != nil {
+		return asErrors(err)
+	}
+	recv, err := b.getObjOrPrepare(v)
+	if err != nil {
+		return asErrors(err)
+	}
+	if err := b.bindBody(recv, v, bodyBytes, contentType, pathParams); err != nil {
+		return asErrors(err)
+	}
+	if recv.hasVd {
+		return asErrors(b.validate(recv, v))
+	}
+	return nil
+}
+
+// BindBody is Bind for callers that already have the request body in hand.
+func (b *Binding) BindBody(structPointer interface{}, bodyBytes []byte, contentType string, pathParams PathParams) error {
+	v, err := b.structValueOf(structPointer)
+	if err != nil {
+		return asErrors(err)
+	}
+	recv, err := b.getObjOrPrepare(v)
+	if err != nil {
+		return asErrors(err)
+	}
+	return asErrors(b.bindBody(recv, v, bodyBytes, contentType, pathParams))
+}
+
+// bindBody is bind() specialized for a caller-supplied body: there is no
+// *http.Request, so only path-tagged fields (from pathParams) and body/
+// raw_body/auto-tagged fields (from bodyBytes/contentType) can be filled;
+// query, header and cookie fields are left untouched.
+func (b *Binding) bindBody(recv *receiver, value reflect.Value, bodyBytes []byte, contentType string, pathParams PathParams) error {
+	expr, err := b.vd.VM().Run(value)
+	if err != nil {
+		return err
+	}
+
+	var codec BodyCodec
+	var postForm url.Values
+	if isFormContentType(contentType) {
+		postForm, err = url.ParseQuery(string(bodyBytes))
+		if err != nil {
+			return err
+		}
+	} else if recv.hasBody || recv.hasAuto {
+		codec = b.bodyCodecFor(contentType)
+		if codec != nil && len(bodyBytes) > 0 {
+			if err = codec.Unmarshal(bodyBytes, value, recv.bodyParams()); err != nil {
+				return b.bindErrFactory("", err.Error())
+			}
+		}
+	}
+
+	for _, param := range recv.params {
+		var bindErr error
+		switch param.in {
+		case path:
+			_, bindErr = param.bindPath(expr, pathParams)
+		case raw_body:
+			bindErr = param.bindRawBody(expr, bodyBytes)
+		case body, auto:
+			if codec == nil {
+				_, bindErr = param.bindForm(expr, postForm)
+			}
+		}
+		if bindErr != nil {
+			return bindErr
+		}
+	}
+
+	for _, param := range recv.params {
+		if param.required && isEmptyValue(param.fieldValue(expr, false)) {
+			return b.bindErrFactory(param.fieldSelector, "required")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package binding
+
+import (
+	"testing"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"gopkg.in/yaml.v2"
+)
+
+// TestJSONCodecSelectivelyDecodesBodyFields exercises jsonCodec's
+// unmarshalRawFields path end-to-end: only the body-tagged fields present
+// in the payload are decoded, and a body-tagged field absent from the
+// payload is left untouched.
+func TestJSONCodecSelectivelyDecodesBodyFields(t *testing.T) {
+	type Req struct {
+		Name string `api:"body:'name'"`
+		Age  int    `api:"body:'age'"`
+	}
+
+	b := New("")
+	out := &Req{Age: 99}
+	err := b.BindBodyAndValidate(out, []byte(`{"name":"ada"}`), "application/json", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Name != "ada" {
+		t.Errorf("Name = %q, want %q", out.Name, "ada")
+	}
+	if out.Age != 99 {
+		t.Errorf("Age = %d, want 99 (untouched: absent from the payload)", out.Age)
+	}
+}
+
+// TestMsgpackCodecSelectivelyDecodesBodyFields is jsonCodec's test above,
+// against msgpackCodec's equivalent unmarshalRawFields path.
+func TestMsgpackCodecSelectivelyDecodesBodyFields(t *testing.T) {
+	type Req struct {
+		Name string `api:"body:'name'"`
+		Age  int    `api:"body:'age'"`
+	}
+
+	payload, err := msgpack.Marshal(map[string]interface{}{"name": "ada"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := New("")
+	out := &Req{Age: 99}
+	if err := b.BindBodyAndValidate(out, payload, "application/x-msgpack", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Name != "ada" {
+		t.Errorf("Name = %q, want %q", out.Name, "ada")
+	}
+	if out.Age != 99 {
+		t.Errorf("Age = %d, want 99 (untouched: absent from the payload)", out.Age)
+	}
+}
+
+// TestYAMLCodecDecodesWholeBody exercises yamlCodec's whole-body
+// yaml.Unmarshal path (no per-field selective decode, unlike json/msgpack).
+func TestYAMLCodecDecodesWholeBody(t *testing.T) {
+	type Req struct {
+		Name string
+		Age  int
+	}
+
+	payload, err := yaml.Marshal(&Req{Name: "ada", Age: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := New("")
+	out := new(Req)
+	if err := b.BindBodyAndValidate(out, payload, "application/x-yaml", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Name != "ada" || out.Age != 30 {
+		t.Errorf("got %+v, want {Name:ada Age:30}", out)
+	}
+}
+
+// TestXMLCodecDecodesWholeBody exercises xmlCodec's whole-body
+// xml.Unmarshal path.
+func TestXMLCodecDecodesWholeBody(t *testing.T) {
+	type Req struct {
+		Name string
+		Age  int
+	}
+
+	payload := []byte(`<Req><Name>ada</Name><Age>30</Age></Req>`)
+
+	b := New("")
+	out := new(Req)
+	if err := b.BindBodyAndValidate(out, payload, "application/xml", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Name != "ada" || out.Age != 30 {
+		t.Errorf("got %+v, want {Name:ada Age:30}", out)
+	}
+}
+
+// TestProtobufCodecDecodesMessage exercises protobufCodec's proto.Unmarshal
+// path. The bound struct must itself implement proto.Message, so this uses
+// a well-known wrapper type rather than a hand-rolled struct.
+func TestProtobufCodecDecodesMessage(t *testing.T) {
+	payload, err := proto.Marshal(wrapperspb.String("ada"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := New("")
+	out := new(wrapperspb.StringValue)
+	if err := b.BindBodyAndValidate(out, payload, "application/x-protobuf", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Value != "ada" {
+		t.Errorf("Value = %q, want %q", out.Value, "ada")
+	}
+}
+
+// TestProtobufCodecRejectsNonProtoMessage guards protobufCodec's type
+// assertion: a struct that doesn't implement proto.Message must fail with
+// a clear error instead of panicking.
+func TestProtobufCodecRejectsNonProtoMessage(t *testing.T) {
+	type Req struct {
+		Name string
+	}
+
+	b := New("")
+	out := new(Req)
+	err := b.BindBodyAndValidate(out, []byte("not actually protobuf"), "application/x-protobuf", nil)
+	if err == nil {
+		t.Fatal("expected an error for a struct that does not implement proto.Message")
+	}
+}
@@ -0,0 +1,127 @@
+package binding
+
+import (
+	"context"
+	"net/http"
+	"runtime"
+	"strconv"
+	"sync"
+
+	"github.com/bytedance/go-tagexpr/validator"
+)
+
+// ctxByGoroutine associates the context.Context of an in-flight
+// BindAndValidateWithContext/ValidateWithContext call with
+// the goroutine running it, so context-aware validator funcs registered via
+// RegisterValidateFuncContext can recover the right context without
+// changing the underlying validator.Validator call signature. Keying by
+// goroutine rather than by struct pointer or a single shared variable is
+// what makes this safe under concurrent requests: two goroutines validating
+// two different requests (of the same or different struct types) each see
+// only their own context, never one another's.
+var ctxByGoroutine sync.Map // map[int64]context.Context
+
+// goroutineID extracts the calling goroutine's numeric ID from its stack
+// trace header ("goroutine 123 [running]: ..."). It is the standard trick
+// for goroutine-local storage in the absence of a language-level facility;
+// it costs one small stack capture per *WithContext call, plus one per call
+// of a context-aware validator func.
+func goroutineID() int64 {
+	var buf [64]byte
+	n := runtime.Stack(buf[:], false)
+	s := buf[len("goroutine "):n]
+	for i, c := range s {
+		if c == ' ' {
+			id, _ := strconv.ParseInt(string(s[:i]), 10, 64)
+			return id
+		}
+	}
+	return 0
+}
+
+func rememberContext(ctx context.Context) (forget func()) {
+	id := goroutineID()
+	ctxByGoroutine.Store(id, ctx)
+	return func() { ctxByGoroutine.Delete(id) }
+}
+
+// BindAndValidateWithContext is BindAndValidate with ctx threaded down to
+// every param binder and to custom validator funcs registered via
+// RegisterValidateFuncContext, so they can consult request-scoped data or
+// abort expensive checks (DB lookups, remote calls) once ctx is done.
+func (b *Binding) BindAndValidateWithContext(ctx context.Context, structPointer interface{}, req *http.Request, pathParams PathParams) error {
+	v, err := b.structValueOf(structPointer)
+	if err != nil {
+		return asErrors(err)
+	}
+	forget := rememberContext(ctx)
+	defer forget()
+	hasVd, err := b.bind(ctx, v, req, pathParams)
+	if err != nil {
+		return asErrors(err)
+	}
+	if hasVd {
+		recv, _ := b.getObjOrPrepare(v)
+		return asErrors(b.validate(recv, v))
+	}
+	return nil
+}
+
+// BindWithContext is Bind with ctx threaded down to every param binder.
+func (b *Binding) BindWithContext(ctx context.Context, structPointer interface{}, req *http.Request, pathParams PathParams) error {
+	v, err := b.structValueOf(structPointer)
+	if err != nil {
+		return asErrors(err)
+	}
+	_, err = b.bind(ctx, v, req, pathParams)
+	return asErrors(err)
+}
+
+// ValidateWithContext is Validate with ctx made available to custom
+// validator funcs registered via RegisterValidateFuncContext.
+func (b *Binding) ValidateWithContext(ctx context.Context, value interface{}) error {
+	v, err := b.structValueOf(value)
+	if err != nil {
+		return asErrors(err)
+	}
+	forget := rememberContext(ctx)
+	defer forget()
+	recv, err := b.getObjOrPrepare(v)
+	if err != nil {
+		return asErrors(err)
+	}
+	return asErrors(b.validate(recv, v))
+}
+
+// CtxValidateFunc is a custom validator function that can read ctx, e.g. to
+// look up tenant/user/deadline/tracing data or cancel an expensive check
+// (DB lookup, remote call) when ctx is done.
+type CtxValidateFunc func(ctx context.Context, fieldValue interface{}) error
+
+// RegisterValidateFuncContext registers a context-aware custom validator
+// function under name, usable from api-tag expressions the same way as a
+// func registered through the validator package's context-less form.
+// NOTE:
+//  ctx is recovered from the goroutine that called
+//  BindAndValidateWithContext/ValidateWithContext; calling
+//  the validated expression outside of one of those entry points, or from a
+//  goroutine spawned inside one, yields context.Background().
+func (b *Binding) RegisterValidateFuncContext(name string, fn CtxValidateFunc) error {
+	return validator.RegFunc(name, func(args ...interface{}) error {
+		var fieldValue interface{}
+		if len(args) > 0 {
+			fieldValue = args[0]
+		}
+		return fn(currentContext(), fieldValue)
+	})
+}
+
+// currentContext returns the context.Context of whichever *WithContext call
+// is presently validating on this goroutine, or context.Background() if
+// there is none.
+func currentContext() context.Context {
+	if ctx, ok := ctxByGoroutine.Load(goroutineID()); ok {
+		return ctx.(context.Context)
+	}
+	return context.Background()
+}
@@ -0,0 +1,43 @@
+package binding
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+type ctxTestKey struct{}
+
+func TestContextDoesNotLeakAcrossConcurrentCalls(t *testing.T) {
+	type Req struct {
+		Token string `api:"$!='' && ctxcheck($)"`
+	}
+
+	b := New("")
+	if err := b.RegisterValidateFuncContext("ctxcheck", func(ctx context.Context, fieldValue interface{}) error {
+		want, _ := ctx.Value(ctxTestKey{}).(string)
+		got, _ := fieldValue.(string)
+		if want != got {
+			return fmt.Errorf("context leaked: want token %q, validator saw %q", want, got)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	const n = 50
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			token := fmt.Sprintf("token-%d", i)
+			ctx := context.WithValue(context.Background(), ctxTestKey{}, token)
+			out := &Req{Token: token}
+			errs <- b.ValidateWithContext(ctx, out)
+		}(i)
+	}
+	for i := 0; i < n; i++ {
+		if err := <-errs; err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package binding
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var indexSelectorRegexp = regexp.MustCompile(`\[(\d+)\]`)
+
+// FieldError is a structured binding or validation failure for a single
+// struct field. When the field is a slice or array element, Index reports
+// which element failed so callers do not have to parse the message text.
+type FieldError struct {
+	field string
+	path  string
+	index int
+	msg   string
+}
+
+// Field returns the failing field's selector with any slice/array indices
+// stripped, e.g. "Items.Name" for a failure on "Items[3].Name".
+func (e *FieldError) Field() string {
+	return e.field
+}
+
+// Index returns the index of the failing slice/array element, or -1 if the
+// field is not (nested under) a slice/array.
+func (e *FieldError) Index() int {
+	return e.index
+}
+
+// Path returns the raw, JSON-path-like selector of the failing field, e.g.
+// "Items[3].Name".
+func (e *FieldError) Path() string {
+	return e.path
+}
+
+// Error implements the error interface.
+func (e *FieldError) Error() string {
+	return e.msg
+}
+
+// newFieldError builds a FieldError from the raw failField selector and
+// message produced by the binding/validator machinery.
+func newFieldError(failField, msg string) *FieldError {
+	index := -1
+	if m := indexSelectorRegexp.FindStringSubmatch(failField); m != nil {
+		index, _ = strconv.Atoi(m[1])
+	}
+	return &FieldError{
+		field: indexSelectorRegexp.ReplaceAllString(failField, ""),
+		path:  failField,
+		index: index,
+		msg:   msg,
+	}
+}
+
+// Errors aggregates one or more FieldError values so a single BindAndValidate
+// call can report every failing field instead of only the first one.
+type Errors []*FieldError
+
+// Error implements the error interface, joining every underlying message.
+func (es Errors) Error() string {
+	msgs := make([]string, len(es))
+	for i, e := range es {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap exposes the underlying errors so callers can use errors.As/errors.Is
+// to inspect an individual field failure.
+func (es Errors) Unwrap() []error {
+	errs := make([]error, len(es))
+	for i, e := range es {
+		errs[i] = e
+	}
+	return errs
+}
+
+func newDefaultErrorFactory(errType string) func(failField, msg string) error {
+	return func(failField, msg string) error {
+		if msg == "" {
+			msg = errType
+		}
+		return newFieldError(failField, errType+": "+msg)
+	}
+}
+
+// asErrors normalizes the error returned by the underlying bind/validate
+// machinery into an *Errors* aggregate so callers can recover the
+// individual FieldError values, even when only one field failed. Any other
+// error (e.g. one built by a custom error factory) is returned unchanged.
+func asErrors(err error) error {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case Errors:
+		return e
+	case *FieldError:
+		return Errors{e}
+	default:
+		return err
+	}
+}
@@ -0,0 +1,49 @@
+package binding
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestBindAndValidateSliceElementMatchExpr is a regression test for a bug
+// where a struct's only match expression living on a slice element's field
+// (not on the slice field itself, nor on any top-level field) never ran at
+// all: expr.RangeFields, used at registration time to decide recv.hasVd,
+// only visits statically-reachable struct fields and never descends into a
+// slice/array/map element, so hasVd stayed false and Binding.validate
+// short-circuited before ever evaluating Item.Name's expression.
+func TestBindAndValidateSliceElementMatchExpr(t *testing.T) {
+	type Item struct {
+		Name string `api:"$!=''"`
+	}
+	type Req struct {
+		Items []Item `api:"body:'items'"`
+	}
+
+	b := New("")
+	out := new(Req)
+	body := `{"items":[{"Name":"a"},{"Name":""},{"Name":"c"}]}`
+	req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	bindErr := b.BindAndValidate(out, req, nil)
+	if bindErr == nil {
+		t.Fatal("expected a validation error for Items[1].Name being empty")
+	}
+
+	var fieldErrs Errors
+	if !errors.As(bindErr, &fieldErrs) {
+		t.Fatalf("expected Errors, got %T: %v", bindErr, bindErr)
+	}
+	if len(fieldErrs) != 1 {
+		t.Fatalf("expected exactly one FieldError, got %d: %v", len(fieldErrs), fieldErrs)
+	}
+	if got := fieldErrs[0].Index(); got != 1 {
+		t.Errorf("Index() = %d, want 1 (the failing element)", got)
+	}
+}
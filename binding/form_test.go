@@ -0,0 +1,39 @@
+package binding
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// TestBindAndValidateFormWithAutoField is a regression test for the double
+// body-drain bug: a struct with an untagged ("auto") field used to make
+// bind() read the whole body as raw bytes first, leaving nothing for
+// ParseForm to read afterwards, so every form field silently came back
+// empty.
+func TestBindAndValidateFormWithAutoField(t *testing.T) {
+	type Req struct {
+		Name string
+		Age  int `api:"query:'age'"`
+	}
+
+	form := url.Values{"Name": {"ada"}}
+	req, err := http.NewRequest(http.MethodPost, "/?age=30", strings.NewReader(form.Encode()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	b := New("")
+	out := new(Req)
+	if err := b.BindAndValidate(out, req, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Name != "ada" {
+		t.Errorf("Name = %q, want %q (form field lost to double body drain)", out.Name, "ada")
+	}
+	if out.Age != 30 {
+		t.Errorf("Age = %d, want 30", out.Age)
+	}
+}
@@ -0,0 +1,88 @@
+package binding
+
+import (
+	"reflect"
+
+	"github.com/bytedance/go-tagexpr"
+	"github.com/bytedance/go-tagexpr/validator"
+)
+
+// omitempty governs, together with required, whether an absent field's
+// match expression (the bare `$...` clause of the `api` tag) runs against
+// its zero value or never runs at all. The truth table is:
+//
+//	empty + required  -> binding fails with a "required" FieldError
+//	empty + optional  -> the match expression is never evaluated
+//	non-empty         -> the match expression runs as usual
+//
+// A field is "optional" here when it carries `omitempty` and not `required`.
+func (r *receiver) omitemptySkipSet(expr *tagexpr.TagExpr) map[string]bool {
+	var skip map[string]bool
+	for _, p := range r.params {
+		if p.omitempty && !p.required && isEmptyValue(p.fieldValue(expr, false)) {
+			if skip == nil {
+				skip = make(map[string]bool, len(r.params))
+			}
+			skip[p.fieldSelector] = true
+		}
+	}
+	return skip
+}
+
+// validate walks value's match expressions (the bare `$...` clause of the
+// `api` tag) via expr.Range, short-circuiting entirely - never calling
+// Eval - for a field omitemptySkipSet determined should be skipped. That
+// matters for a custom validator func registered via validator.RegFunc/
+// RegisterValidateFuncContext: an expensive one (a DB lookup, an external
+// API call) must not pay its cost just to have the result thrown away for
+// a field the request left unset.
+//
+// expr.Range is used instead of FieldHandler.EvalFuncs, which passes the
+// expression's own name (e.g. "@") as the $ selector's field context
+// instead of the field's real name, breaking any match expression that
+// references $.
+func (b *Binding) validate(recv *receiver, value reflect.Value) error {
+	if !recv.hasVd {
+		return nil
+	}
+	expr, err := b.vd.VM().Run(value)
+	if err != nil {
+		return err
+	}
+	skip := recv.omitemptySkipSet(expr)
+	var errs Errors
+	rangeErr := expr.Range(func(eh *tagexpr.ExprHandler) error {
+		es := eh.ExprSelector()
+		if es.Name() != validator.MatchExprName {
+			return nil
+		}
+		field := es.Field()
+		if skip[field] {
+			return nil
+		}
+		r := eh.Eval()
+		if r == nil || tagexpr.FakeBool(r) {
+			return nil
+		}
+		var msg string
+		if rerr, ok := r.(error); ok {
+			msg = rerr.Error()
+		}
+		if msg == "" {
+			msg = eh.TagExpr().EvalString(field + tagexpr.ExprNameSeparator + validator.ErrMsgExprName)
+		}
+		verr := b.validatingErrFactory(eh.Path(), msg)
+		if fe, ok := verr.(*FieldError); ok {
+			errs = append(errs, fe)
+			return nil
+		}
+		return verr
+	})
+	if rangeErr != nil {
+		return rangeErr
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
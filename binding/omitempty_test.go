@@ -0,0 +1,129 @@
+package binding
+
+import (
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/bytedance/go-tagexpr/validator"
+)
+
+// TestOmitemptySkipsEmptyOptionalField is an end-to-end regression test: an
+// empty, omitempty, non-required field must bind and validate successfully
+// even though its match expression would fail on the zero value.
+func TestOmitemptySkipsEmptyOptionalField(t *testing.T) {
+	type Req struct {
+		URL string `api:"query:'url';omitempty:true;regexp('^https?://')"`
+	}
+
+	b := New("")
+	out := new(Req)
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := b.BindAndValidate(out, req, nil); err != nil {
+		t.Fatalf("unexpected error for empty omitempty field: %v", err)
+	}
+}
+
+// TestOmitemptyStillValidatesNonEmptyField ensures omitempty only skips the
+// zero value, not the field entirely.
+func TestOmitemptyStillValidatesNonEmptyField(t *testing.T) {
+	type Req struct {
+		URL string `api:"query:'url';omitempty:true;regexp('^https?://')"`
+	}
+
+	b := New("")
+	out := new(Req)
+	req, err := http.NewRequest(http.MethodGet, "/?"+url.Values{"url": {"not-a-url"}}.Encode(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := b.BindAndValidate(out, req, nil); err == nil {
+		t.Fatal("expected a validation error for a non-empty field failing its match expression")
+	}
+}
+
+// TestOmitemptyRequiredFieldNeverSkipped guards the documented truth table:
+// required always wins over omitempty, so an empty required field fails
+// binding regardless of the omitempty tag.
+func TestOmitemptyRequiredFieldNeverSkipped(t *testing.T) {
+	type Req struct {
+		Email string `api:"query:'email';required:true;omitempty:true"`
+	}
+
+	b := New("")
+	out := new(Req)
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := b.BindAndValidate(out, req, nil); err == nil {
+		t.Fatal("expected a required error for an empty required+omitempty field")
+	}
+}
+
+// TestOmitemptyShortCircuitsValidatorFunc is a regression test for the
+// post-hoc-filtering bug: a custom validator func registered for an
+// omitempty field's match expression must never be invoked at all when the
+// field is left empty, not merely have its result discarded afterwards.
+func TestOmitemptyShortCircuitsValidatorFunc(t *testing.T) {
+	var calls int32
+	const funcName = "countedCheck"
+	if err := validator.RegFunc(funcName, func(args ...interface{}) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	}, true); err != nil {
+		t.Fatal(err)
+	}
+
+	type Req struct {
+		Code string `api:"query:'code';omitempty:true;countedCheck($)"`
+	}
+
+	b := New("")
+	out := new(Req)
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := b.BindAndValidate(out, req, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 0 {
+		t.Fatalf("%s was called %d times for an omitempty-skipped field, want 0", funcName, n)
+	}
+
+	out2 := new(Req)
+	req2, err := http.NewRequest(http.MethodGet, "/?"+url.Values{"code": {"x"}}.Encode(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := b.BindAndValidate(out2, req2, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Fatalf("%s was called %d times for a non-empty field, want 1", funcName, n)
+	}
+}
+
+// TestValidateStandalone exercises Validate directly (no request at all),
+// covering the same omitempty short-circuit via the Binding.validate path.
+func TestValidateStandalone(t *testing.T) {
+	type Req struct {
+		URL string `api:"omitempty:true;regexp('^https?://')"`
+	}
+
+	b := New("")
+	if err := b.Validate(&Req{}); err != nil {
+		t.Fatalf("unexpected error for empty omitempty field: %v", err)
+	}
+	if err := b.Validate(&Req{URL: "ftp://example.com"}); err == nil {
+		t.Fatal("expected a validation error for a non-matching non-empty field")
+	}
+	if err := b.Validate(&Req{URL: "https://example.com"}); err != nil {
+		t.Fatalf("unexpected error for a matching non-empty field: %v", err)
+	}
+}
@@ -0,0 +1,301 @@
+package binding
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+
+	"github.com/bytedance/go-tagexpr"
+)
+
+// PathParams is implemented by the router adapter that resolves the path
+// parameters of a request, e.g. `:id` in `/user/:id`.
+type PathParams interface {
+	// Get returns the value of the path parameter named by key.
+	// The second return value reports whether the key was present.
+	Get(key string) (string, bool)
+}
+
+// paramIn identifies where a struct field's bound value comes from.
+type paramIn int8
+
+const (
+	auto paramIn = iota
+	path
+	query
+	header
+	cookie
+	body
+	raw_body
+)
+
+// paramInfo holds the binding metadata collected for a single struct field.
+type paramInfo struct {
+	fieldSelector string
+	name          string
+	in            paramIn
+	required      bool
+	omitempty     bool
+}
+
+// fieldValue resolves p's field against expr, the *tagexpr.TagExpr built
+// for the specific struct value currently being bound or validated. expr
+// must come from a fresh b.vd.VM().Run(value) call against that very
+// value; a *tagexpr.TagExpr built against any other instance (e.g. the
+// throwaway one getObjOrPrepare uses to discover params) would read or
+// write through to the wrong struct entirely.
+func (p *paramInfo) fieldValue(expr *tagexpr.TagExpr, initZero bool) reflect.Value {
+	fh, found := expr.Field(p.fieldSelector)
+	if !found {
+		return reflect.Value{}
+	}
+	return fh.Value(initZero)
+}
+
+// getOrAddParam appends a new paramInfo for the field described by fh to
+// r.params and returns it; it does not look up an existing one.
+func (r *receiver) getOrAddParam(fh *tagexpr.FieldHandler, bindErrFactory func(failField, msg string) error) *paramInfo {
+	p := &paramInfo{
+		fieldSelector: fh.StringSelector(),
+	}
+	r.params = append(r.params, p)
+	return p
+}
+
+// initParams finalizes the receiver once all of its params are collected,
+// e.g. ordering params so body/raw_body are bound last.
+func (r *receiver) initParams() {
+	sort := make([]*paramInfo, 0, len(r.params))
+	var deferred []*paramInfo
+	for _, p := range r.params {
+		switch p.in {
+		case body, raw_body, auto:
+			deferred = append(deferred, p)
+		default:
+			sort = append(sort, p)
+		}
+	}
+	r.params = append(sort, deferred...)
+}
+
+// bodyParams returns the params whose value comes from the request body,
+// for use by a BodyCodec.
+func (r *receiver) bodyParams() []*paramInfo {
+	params := make([]*paramInfo, 0, len(r.params))
+	for _, p := range r.params {
+		if p.in == body || p.in == auto {
+			params = append(params, p)
+		}
+	}
+	return params
+}
+
+// receiver caches the binding plan for one struct type so it need not be
+// recomputed on every request.
+type receiver struct {
+	params     []*paramInfo
+	hasVd      bool
+	hasAuto    bool
+	hasPath    bool
+	hasQuery   bool
+	hasBody    bool
+	hasCookie  bool
+	hasRawBody bool
+}
+
+func (r *receiver) getBodyBytes(req *http.Request, mustRead bool) ([]byte, error) {
+	if req == nil || !mustRead {
+		return nil, nil
+	}
+	if body, ok := cachedPeekedBody(req); ok {
+		return body, nil
+	}
+	if req.Body == nil {
+		return nil, nil
+	}
+	return readAllBody(req)
+}
+
+func (r *receiver) getPostForm(req *http.Request, mustForm bool) (url.Values, error) {
+	if req == nil || !mustForm {
+		return nil, nil
+	}
+	if err := req.ParseMultipartForm(defaultMaxMemory); err != nil && err != http.ErrNotMultipart {
+		if err := req.ParseForm(); err != nil {
+			return nil, err
+		}
+	}
+	return req.PostForm, nil
+}
+
+func (r *receiver) getQuery(req *http.Request) url.Values {
+	if req == nil || req.URL == nil {
+		return nil
+	}
+	return req.URL.Query()
+}
+
+func (r *receiver) getCookies(req *http.Request) []*http.Cookie {
+	if req == nil {
+		return nil
+	}
+	return req.Cookies()
+}
+
+const defaultMaxMemory = 32 << 20
+
+// readAllBody reads req.Body in full, the same way PeekBody does, so a read
+// failure (a reset connection, a body closed early) is reported to the
+// caller instead of silently truncating the body it hands to binding.
+func readAllBody(req *http.Request) ([]byte, error) {
+	return ioutil.ReadAll(req.Body)
+}
+
+func (p *paramInfo) bindQuery(expr *tagexpr.TagExpr, values url.Values) (bool, error) {
+	if values == nil {
+		return false, nil
+	}
+	strs, ok := values[p.name]
+	if !ok {
+		return false, nil
+	}
+	return true, p.bindStrings(expr, strs)
+}
+
+func (p *paramInfo) bindPath(expr *tagexpr.TagExpr, pathParams PathParams) (bool, error) {
+	if pathParams == nil {
+		return false, nil
+	}
+	str, ok := pathParams.Get(p.name)
+	if !ok {
+		return false, nil
+	}
+	return true, p.bindStrings(expr, []string{str})
+}
+
+func (p *paramInfo) bindHeader(expr *tagexpr.TagExpr, header http.Header) (bool, error) {
+	strs, ok := header[http.CanonicalHeaderKey(p.name)]
+	if !ok {
+		return false, nil
+	}
+	return true, p.bindStrings(expr, strs)
+}
+
+func (p *paramInfo) bindCookie(expr *tagexpr.TagExpr, cookies []*http.Cookie) error {
+	for _, c := range cookies {
+		if c.Name == p.name {
+			return p.bindStrings(expr, []string{c.Value})
+		}
+	}
+	return nil
+}
+
+// bindForm binds a field from application/x-www-form-urlencoded or
+// multipart/form-data values. Structured body formats (JSON, MsgPack,
+// YAML, protobuf, XML) are decoded separately by a BodyCodec before the
+// per-param loop runs.
+func (p *paramInfo) bindForm(expr *tagexpr.TagExpr, postForm url.Values) (bool, error) {
+	if postForm == nil {
+		return false, nil
+	}
+	strs, ok := postForm[p.name]
+	if !ok {
+		return false, nil
+	}
+	return true, p.bindStrings(expr, strs)
+}
+
+func (p *paramInfo) bindRawBody(expr *tagexpr.TagExpr, bodyBytes []byte) error {
+	v := p.fieldValue(expr, true)
+	if !v.IsValid() {
+		return nil
+	}
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+		v.SetBytes(bodyBytes)
+		return nil
+	}
+	return setValue(v, string(bodyBytes))
+}
+
+// bindStrings assigns the first of strs to the field, parsing it according
+// to the field's kind; any further values are ignored, and a field of a kind
+// setValue does not handle (e.g. a slice) is left unchanged.
+func (p *paramInfo) bindStrings(expr *tagexpr.TagExpr, strs []string) error {
+	if len(strs) == 0 {
+		return nil
+	}
+	v := p.fieldValue(expr, true)
+	if !v.IsValid() {
+		return nil
+	}
+	return setValue(v, strs[0])
+}
+
+func setValue(v reflect.Value, str string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(str)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(str)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(str, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	default:
+		return nil
+	}
+	return nil
+}
+
+// isEmptyValue reports whether v is the zero value of its type, e.g. "",
+// 0 or a nil pointer/slice/map; a non-nil slice or map with no elements is
+// not empty.
+func isEmptyValue(v reflect.Value) bool {
+	if !v.IsValid() {
+		return true
+	}
+	return v.IsZero()
+}
+
+func derefValue(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			if !v.CanSet() {
+				return v
+			}
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// getParamName returns the tag-configured name for a param, falling back
+// to fallback (typically the field's own name) when the tag value is empty.
+func getParamName(eval func() interface{}, fallback string) (name string, errMsg string) {
+	r, ok := eval().(string)
+	if !ok || r == "" {
+		return fallback, ""
+	}
+	return r, ""
+}
@@ -0,0 +1,227 @@
+package binding
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/bytedance/go-tagexpr"
+)
+
+// BindURI binds only the path-tagged fields of structPointer from
+// pathParams, skipping query/header/cookie/body entirely. This lets a
+// router that has already resolved path parameters validate the URI
+// before touching the request body, and lets non-HTTP transports (CLI
+// subcommands, gRPC method options) reuse the same tag-driven structs.
+func (b *Binding) BindURI(structPointer interface{}, pathParams PathParams) error {
+	return b.bindOneSource(structPointer, isIn(path), func(p *paramInfo, expr *tagexpr.TagExpr) error {
+		_, err := p.bindPath(expr, pathParams)
+		return err
+	})
+}
+
+// BindQuery binds only the query-tagged fields of structPointer from
+// values, plus any untagged ("auto") field, matching the fallback the
+// composed bind() gives an auto field when no request body is read.
+func (b *Binding) BindQuery(structPointer interface{}, values url.Values) error {
+	return b.bindOneSource(structPointer, isIn(query, auto), func(p *paramInfo, expr *tagexpr.TagExpr) error {
+		_, err := p.bindQuery(expr, values)
+		return err
+	})
+}
+
+// BindHeader binds only the header-tagged fields of structPointer from hdr.
+func (b *Binding) BindHeader(structPointer interface{}, hdr http.Header) error {
+	return b.bindOneSource(structPointer, isIn(header), func(p *paramInfo, expr *tagexpr.TagExpr) error {
+		_, err := p.bindHeader(expr, hdr)
+		return err
+	})
+}
+
+// BindCookie binds only the cookie-tagged fields of structPointer from cookies.
+func (b *Binding) BindCookie(structPointer interface{}, cookies []*http.Cookie) error {
+	return b.bindOneSource(structPointer, isIn(cookie), func(p *paramInfo, expr *tagexpr.TagExpr) error {
+		return p.bindCookie(expr, cookies)
+	})
+}
+
+// BindForm binds only the body-tagged fields of structPointer from
+// postForm, plus any untagged ("auto") field, the form-encoded counterpart
+// of BindBody for a structured body.
+func (b *Binding) BindForm(structPointer interface{}, postForm url.Values) error {
+	return b.bindOneSource(structPointer, isIn(body, auto), func(p *paramInfo, expr *tagexpr.TagExpr) error {
+		_, err := p.bindForm(expr, postForm)
+		return err
+	})
+}
+
+// isIn returns a predicate matching any of ins, for use with bindOneSource.
+func isIn(ins ...paramIn) func(paramIn) bool {
+	return func(in paramIn) bool {
+		for _, want := range ins {
+			if in == want {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// bindOneSource runs bindOne over every param of structPointer whose `in`
+// satisfies match, skipping every other source entirely, then enforces
+// required on those same params - mirroring the trailing required-check
+// loop in the composed *Binding.bind and bindBody. The check is scoped to
+// only the params match selects, not recv.params as a whole: a standalone
+// BindQuery has no chance to populate a required header field, so it must
+// not fail on one.
+func (b *Binding) bindOneSource(structPointer interface{}, match func(paramIn) bool, bindOne func(*paramInfo, *tagexpr.TagExpr) error) error {
+	v, err := b.structValueOf(structPointer)
+	if err != nil {
+		return asErrors(err)
+	}
+	recv, err := b.getObjOrPrepare(v)
+	if err != nil {
+		return asErrors(err)
+	}
+	expr, err := b.vd.VM().Run(v)
+	if err != nil {
+		return asErrors(err)
+	}
+	for _, p := range recv.params {
+		if !match(p.in) {
+			continue
+		}
+		if err := bindOne(p, expr); err != nil {
+			return asErrors(err)
+		}
+	}
+	for _, p := range recv.params {
+		if !match(p.in) {
+			continue
+		}
+		if p.required && isEmptyValue(p.fieldValue(expr, false)) {
+			return asErrors(b.bindErrFactory(p.fieldSelector, "required"))
+		}
+	}
+	return nil
+}
+
+// The param-group helpers below back *Binding.bind, composing the very
+// same per-source primitives that BindURI/BindQuery/BindHeader/BindCookie/
+// BindForm expose publicly, instead of re-deciding per field in one big
+// switch.
+
+func bindPathParams(ctx context.Context, params []*paramInfo, expr *tagexpr.TagExpr, pathParams PathParams) error {
+	for _, p := range params {
+		if p.in != path {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, err := p.bindPath(expr, pathParams); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func bindQueryParams(ctx context.Context, params []*paramInfo, expr *tagexpr.TagExpr, values url.Values) error {
+	for _, p := range params {
+		if p.in != query {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, err := p.bindQuery(expr, values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func bindHeaderParams(ctx context.Context, params []*paramInfo, expr *tagexpr.TagExpr, hdr http.Header) error {
+	for _, p := range params {
+		if p.in != header {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, err := p.bindHeader(expr, hdr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func bindCookieParams(ctx context.Context, params []*paramInfo, expr *tagexpr.TagExpr, cookies []*http.Cookie) error {
+	for _, p := range params {
+		if p.in != cookie {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := p.bindCookie(expr, cookies); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func bindFormParams(ctx context.Context, params []*paramInfo, expr *tagexpr.TagExpr, postForm url.Values) error {
+	for _, p := range params {
+		if p.in != body {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, err := p.bindForm(expr, postForm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func bindRawBodyParams(params []*paramInfo, expr *tagexpr.TagExpr, bodyBytes []byte) error {
+	for _, p := range params {
+		if p.in != raw_body {
+			continue
+		}
+		if err := p.bindRawBody(expr, bodyBytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bindAutoParams binds untagged fields, preferring postForm then falling
+// back to the query string, unless a structured BodyCodec already decoded
+// the body (see the comment on *Binding.bind for why that skips the
+// fallback).
+func bindAutoParams(ctx context.Context, params []*paramInfo, expr *tagexpr.TagExpr, postForm, queryValues url.Values, codec BodyCodec) error {
+	if codec != nil {
+		return nil
+	}
+	for _, p := range params {
+		if p.in != auto {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		found, err := p.bindForm(expr, postForm)
+		if err != nil {
+			return err
+		}
+		if !found {
+			if _, err := p.bindQuery(expr, queryValues); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
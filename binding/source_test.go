@@ -0,0 +1,152 @@
+package binding
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+// TestBindQueryBindsAutoField is a regression test: a standalone BindQuery
+// call used to bind only query-tagged fields, leaving an untagged ("auto")
+// field untouched even though the composed bind() path falls back to the
+// query string for it.
+func TestBindQueryBindsAutoField(t *testing.T) {
+	type Req struct {
+		Name string
+		Page int `api:"query:'page'"`
+	}
+
+	b := New("")
+	out := new(Req)
+	values := url.Values{"Name": {"ada"}, "page": {"3"}}
+	if err := b.BindQuery(out, values); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Name != "ada" {
+		t.Errorf("Name = %q, want %q (auto field not bound by standalone BindQuery)", out.Name, "ada")
+	}
+	if out.Page != 3 {
+		t.Errorf("Page = %d, want 3", out.Page)
+	}
+}
+
+// TestBindFormBindsAutoField is BindQuery's counterpart for BindForm.
+func TestBindFormBindsAutoField(t *testing.T) {
+	type Req struct {
+		Name string
+		Age  int `api:"body:'age'"`
+	}
+
+	b := New("")
+	out := new(Req)
+	postForm := url.Values{"Name": {"ada"}, "age": {"30"}}
+	if err := b.BindForm(out, postForm); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Name != "ada" {
+		t.Errorf("Name = %q, want %q (auto field not bound by standalone BindForm)", out.Name, "ada")
+	}
+	if out.Age != 30 {
+		t.Errorf("Age = %d, want 30", out.Age)
+	}
+}
+
+// TestBindQueryMissingRequiredFieldFails is a regression test: bindOneSource
+// used to return nil even when a required param it was responsible for
+// bound empty, because unlike the composed bind() and bindBody(), it ran no
+// required check at all.
+func TestBindQueryMissingRequiredFieldFails(t *testing.T) {
+	type Req struct {
+		Token string `api:"query:'token';required:true"`
+	}
+
+	b := New("")
+	out := new(Req)
+	if err := b.BindQuery(out, url.Values{}); err == nil {
+		t.Fatal("expected a required error for an empty required query field")
+	}
+	out2 := new(Req)
+	if err := b.BindQuery(out2, url.Values{"token": {"x"}}); err != nil {
+		t.Fatalf("unexpected error for a populated required field: %v", err)
+	}
+}
+
+// TestBindURIMissingRequiredFieldFails is BindQuery's counterpart for BindURI.
+func TestBindURIMissingRequiredFieldFails(t *testing.T) {
+	type Req struct {
+		ID string `api:"path:'id';required:true"`
+	}
+
+	b := New("")
+	out := new(Req)
+	if err := b.BindURI(out, testPathParams{}); err == nil {
+		t.Fatal("expected a required error for an empty required path field")
+	}
+}
+
+// TestBindHeaderMissingRequiredFieldFails is BindQuery's counterpart for
+// BindHeader.
+func TestBindHeaderMissingRequiredFieldFails(t *testing.T) {
+	type Req struct {
+		Token string `api:"header:'X-Token';required:true"`
+	}
+
+	b := New("")
+	out := new(Req)
+	if err := b.BindHeader(out, http.Header{}); err == nil {
+		t.Fatal("expected a required error for an empty required header field")
+	}
+}
+
+// TestBindCookieMissingRequiredFieldFails is BindQuery's counterpart for
+// BindCookie.
+func TestBindCookieMissingRequiredFieldFails(t *testing.T) {
+	type Req struct {
+		Session string `api:"cookie:'session';required:true"`
+	}
+
+	b := New("")
+	out := new(Req)
+	if err := b.BindCookie(out, nil); err == nil {
+		t.Fatal("expected a required error for an empty required cookie field")
+	}
+}
+
+// TestBindFormMissingRequiredFieldFails is BindQuery's counterpart for
+// BindForm.
+func TestBindFormMissingRequiredFieldFails(t *testing.T) {
+	type Req struct {
+		Age int `api:"body:'age';required:true"`
+	}
+
+	b := New("")
+	out := new(Req)
+	if err := b.BindForm(out, url.Values{}); err == nil {
+		t.Fatal("expected a required error for an empty required form field")
+	}
+}
+
+// TestBindHeaderIgnoresAutoField guards the other half of the fix: sources
+// with no auto fallback in the composed bind() path (header, cookie, path)
+// must keep ignoring untagged fields in their standalone binders too.
+func TestBindHeaderIgnoresAutoField(t *testing.T) {
+	type Req struct {
+		Name  string
+		Token string `api:"header:'X-Token'"`
+	}
+
+	b := New("")
+	out := new(Req)
+	hdr := http.Header{}
+	hdr.Set("X-Token", "secret")
+	hdr.Set("Name", "ada")
+	if err := b.BindHeader(out, hdr); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Token != "secret" {
+		t.Errorf("Token = %q, want %q", out.Token, "secret")
+	}
+	if out.Name != "" {
+		t.Errorf("Name = %q, want empty: BindHeader must not fall back untagged fields", out.Name)
+	}
+}